@@ -8,17 +8,36 @@ package js
 
 import "sync"
 
-var pendingCallbacks = Global.Get("Array").New()
+// pendingCallbacks holds the queue of callback invocations awaiting dispatch by
+// callbackLoop. It is a holder object rather than a bare array so that drainPendingCallbacks
+// can swap in a fresh empty array in a single JS call: every enqueueFn created by
+// makeCallbackHelper or makePromiseCallbackHelper looks up pendingCallbacks.callbacks anew
+// on each push, so the swap is visible to them without re-registering anything.
+var pendingCallbacks = func() Value {
+	v := Global.Get("Object").New()
+	v.Set("callbacks", Global.Get("Array").New())
+	return v
+}()
 
 var makeCallbackHelper = Global.Call("eval", `
 	(function(id, pendingCallbacks, resolveCallbackPromise) {
 		return function() {
-			pendingCallbacks.push({ id: id, args: arguments });
+			pendingCallbacks.callbacks.push({ id: id, args: arguments });
 			resolveCallbackPromise();
 		};
 	})
 `)
 
+var drainPendingCallbacks = Global.Call("eval", `
+	(function(pendingCallbacks) {
+		return function() {
+			var drained = pendingCallbacks.callbacks;
+			pendingCallbacks.callbacks = [];
+			return drained;
+		};
+	})
+`).Invoke(pendingCallbacks)
+
 var makeEventCallbackHelper = Global.Call("eval", `
 	(function(preventDefault, stopPropagation, stopImmediatePropagation, fn) {
 		return function(event) {
@@ -116,11 +135,11 @@ var callbackLoopOnce sync.Once
 func callbackLoop() {
 	for {
 		sleepUntilCallback()
-		for {
-			cb := pendingCallbacks.Call("shift")
-			if cb == Undefined {
-				break
-			}
+
+		drained := drainPendingCallbacks.Invoke()
+		n := drained.Length()
+		for i := 0; i < n; i++ {
+			cb := drained.Index(i)
 
 			id := uint32(cb.Get("id").Int())
 			callbacksMu.Lock()
@@ -133,8 +152,8 @@ func callbackLoop() {
 
 			argsObj := cb.Get("args")
 			args := make([]Value, argsObj.Length())
-			for i := range args {
-				args[i] = argsObj.Index(i)
+			for j := range args {
+				args[j] = argsObj.Index(j)
 			}
 			f(args)
 		}
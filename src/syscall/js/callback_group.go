@@ -0,0 +1,68 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build js,wasm
+
+package js
+
+import "sync"
+
+// A CallbackGroup collects the callbacks registered through it so that they can all be
+// released together with a single Close call, instead of requiring every Callback to be
+// closed individually. This is meant for code that wires up many short-lived listeners at
+// once, such as a page or component that registers a handful of DOM event listeners for as
+// long as it is mounted and must tear all of them down together when it isn't.
+//
+// A CallbackGroup must not be copied after first use.
+type CallbackGroup struct {
+	mu  sync.Mutex
+	cbs []Callback
+}
+
+// NewCallback is like the package-level NewCallback, but the returned Callback is also
+// registered with g, so that a later call to g.Close releases it.
+func (g *CallbackGroup) NewCallback(fn func(args []Value)) Callback {
+	c := NewCallback(fn)
+	g.register(c)
+	return c
+}
+
+// NewEventCallback is like the package-level NewEventCallback, but the returned Callback is
+// also registered with g, so that a later call to g.Close releases it.
+func (g *CallbackGroup) NewEventCallback(flags EventCallbackFlag, fn func(event Value)) Callback {
+	c := NewEventCallback(flags, fn)
+	g.register(c)
+	return c
+}
+
+func (g *CallbackGroup) register(c Callback) {
+	g.mu.Lock()
+	g.cbs = append(g.cbs, c)
+	g.mu.Unlock()
+}
+
+// Close releases every callback registered with g by calling Callback.Close on each of
+// them, and empties g. Close is safe to call more than once; a later call releases only the
+// callbacks registered with g since the previous call, and does nothing if there are none.
+func (g *CallbackGroup) Close() {
+	g.mu.Lock()
+	cs := g.cbs
+	g.cbs = nil
+	g.mu.Unlock()
+
+	for _, c := range cs {
+		c.Close()
+	}
+}
+
+// CallbacksInUse returns the number of callbacks currently registered with the runtime,
+// whether created directly with NewCallback, NewPromiseCallback, or through a
+// CallbackGroup. It is intended for leak detection in tests: a count that keeps growing
+// across test cases that are each supposed to clean up after themselves points at a missing
+// Callback.Close.
+func CallbacksInUse() int {
+	callbacksMu.Lock()
+	defer callbacksMu.Unlock()
+	return len(callbacks)
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build js,wasm
+
+package js
+
+import "testing"
+
+// fillPendingCallbacks resets the pending callback queue and pushes n synthetic entries
+// directly, bypassing makeCallbackHelper, so the benchmarks below measure only the cost of
+// draining the queue, not of enqueuing it.
+func fillPendingCallbacks(n int) {
+	pendingCallbacks.Set("callbacks", Global.Get("Array").New())
+	callbacks := pendingCallbacks.Get("callbacks")
+	for i := 0; i < n; i++ {
+		callbacks.Call("push", Global.Get("Object").New())
+	}
+}
+
+func benchmarkShiftDrain(b *testing.B, n int) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		fillPendingCallbacks(n)
+		b.StartTimer()
+
+		callbacks := pendingCallbacks.Get("callbacks")
+		for {
+			cb := callbacks.Call("shift")
+			if cb == Undefined {
+				break
+			}
+		}
+	}
+}
+
+func benchmarkBatchDrain(b *testing.B, n int) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		fillPendingCallbacks(n)
+		b.StartTimer()
+
+		drainPendingCallbacks.Invoke()
+	}
+}
+
+func BenchmarkCallbackDrainShift1(b *testing.B)    { benchmarkShiftDrain(b, 1) }
+func BenchmarkCallbackDrainShift10(b *testing.B)   { benchmarkShiftDrain(b, 10) }
+func BenchmarkCallbackDrainShift100(b *testing.B)  { benchmarkShiftDrain(b, 100) }
+func BenchmarkCallbackDrainShift1000(b *testing.B) { benchmarkShiftDrain(b, 1000) }
+
+func BenchmarkCallbackDrainBatch1(b *testing.B)    { benchmarkBatchDrain(b, 1) }
+func BenchmarkCallbackDrainBatch10(b *testing.B)   { benchmarkBatchDrain(b, 10) }
+func BenchmarkCallbackDrainBatch100(b *testing.B)  { benchmarkBatchDrain(b, 100) }
+func BenchmarkCallbackDrainBatch1000(b *testing.B) { benchmarkBatchDrain(b, 1000) }
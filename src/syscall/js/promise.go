@@ -0,0 +1,95 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build js,wasm
+
+package js
+
+var makePromiseCallbackHelper = Global.Call("eval", `
+	(function(id, pendingCallbacks, resolveCallbackPromise) {
+		return function() {
+			var callArgs = arguments;
+			return new Promise(function(resolve, reject) {
+				var args = [resolve, reject];
+				for (var i = 0; i < callArgs.length; i++) {
+					args.push(callArgs[i]);
+				}
+				pendingCallbacks.callbacks.push({ id: id, args: args });
+				resolveCallbackPromise();
+			});
+		};
+	})
+`)
+
+// NewPromiseCallback returns a wrapped callback function, just like NewCallback. Invoking
+// the callback in JavaScript immediately returns a new Promise, and queues fn for execution
+// on the callback goroutine exactly like a callback created with NewCallback. Once fn
+// returns, the Promise is resolved with the returned Value, or rejected with a JavaScript
+// Error carrying err's message if err is non-nil.
+//
+// This makes it possible to implement Promise-based JavaScript APIs, such as a custom
+// fetch-like function or an IndexedDB wrapper, using ordinary Go control flow on the Go
+// side instead of manually constructing and settling a Promise for every call.
+//
+// Callback.Close must be called to free up resources when the callback will not be used any more.
+func NewPromiseCallback(fn func(args []Value) (Value, error)) Callback {
+	c := NewCallback(func(args []Value) {
+		resolve, reject := args[0], args[1]
+		result, err := fn(args[2:])
+		if err != nil {
+			reject.Invoke(Global.Get("Error").New(err.Error()))
+			return
+		}
+		resolve.Invoke(result)
+	})
+	return Callback{
+		id:        c.id,
+		enqueueFn: makePromiseCallbackHelper.Invoke(c.id, pendingCallbacks, resolveCallbackPromise),
+	}
+}
+
+// A PromiseRejectedError is returned by Await when the awaited thenable is rejected.
+// Reason is the value it was rejected with, unchanged.
+type PromiseRejectedError struct {
+	Reason Value
+}
+
+func (e *PromiseRejectedError) Error() string {
+	return "js: promise rejected: " + e.Reason.String()
+}
+
+// Await blocks the calling goroutine until the thenable p settles, by attaching a pair of
+// NewCallback callbacks to p.then and parking on a channel until one of them runs. If p
+// fulfills, Await returns the fulfillment value and a nil error. If p rejects, Await returns
+// the zero Value and a *PromiseRejectedError wrapping the rejection reason.
+//
+// Await lets Go code consume the pervasive Promise-based browser APIs, such as fetch,
+// IndexedDB and the Web Crypto API, without hand-rolling a channel for every call.
+//
+// The calling goroutine is blocked until p settles, so Await must not be called from the
+// callback goroutine that NewCallback callbacks run on: doing so would prevent the
+// resolution callback registered by Await itself from ever running, and deadlock.
+func Await(p Value) (Value, error) {
+	type settlement struct {
+		value Value
+		err   error
+	}
+	ch := make(chan settlement, 1)
+
+	var onFulfilled, onRejected Callback
+	onFulfilled = NewCallback(func(args []Value) {
+		ch <- settlement{value: args[0]}
+		onFulfilled.Close()
+		onRejected.Close()
+	})
+	onRejected = NewCallback(func(args []Value) {
+		ch <- settlement{err: &PromiseRejectedError{Reason: args[0]}}
+		onFulfilled.Close()
+		onRejected.Close()
+	})
+	p.Call("then", onFulfilled, onRejected)
+
+	s := <-ch
+	return s.value, s.err
+}